@@ -1,191 +1,80 @@
 package pool
 
 import (
+	"context"
 	"errors"
-	"fmt"
-	"sync"
 	"time"
 )
 
+// PoolConfig configures the interface{}-based Pool. It is a thin shim over
+// GenericPoolConfig[interface{}], kept for callers that predate Go generics
+// support in this package; new code should prefer GenericPoolConfig[T] and
+// NewGenericPool.
 type PoolConfig struct {
 	InitialCap int
 	MaxActive  int
 	MaxIdle    int
+	// MinIdle is the number of idle connections the Check goroutine tries to
+	// keep on hand by refilling via Factory. 0 disables refilling.
+	MinIdle int
+
+	Factory func() (interface{}, error)
+	Close   func(interface{}) error
+	Ping    func(interface{}) error
+	// OnBorrow validates a connection as it is taken out of the pool, the
+	// same way database/sql and commons-pool's "testOnBorrow" do. It is
+	// called with the time the connection has been idle since. Returning an
+	// error discards the connection instead of handing it to the caller.
+	OnBorrow func(conn interface{}, idleSince time.Time) error
+	// TestOnReturn runs Ping inside Put/PutContext and discards the
+	// connection instead of returning it to the pool when it fails.
+	TestOnReturn bool
+	// IdleStrategy picks FIFO (the default) or LIFO idle-connection reuse.
+	// See the IdleStrategy docs for why you'd want LIFO.
+	IdleStrategy IdleStrategy
 
-	Factory       func() (interface{}, error)
-	Close         func(interface{}) error
-	Ping          func(interface{}) error
 	IdleTimeout   time.Duration
 	CheckInterval time.Duration
 }
 
+// channelPool is a thin shim over genericChannelPool[interface{}], kept so
+// existing callers of the interface{}-based Pool never notice the generic
+// core underneath.
 type channelPool struct {
-	mu            sync.Mutex
-	conns         chan *idleConn
-	maxActive     int
-	maxIdle       int
-	curConnCount  int
-	factory       func() (interface{}, error)
-	close         func(interface{}) error
-	ping          func(interface{}) error
-	idleTimeout   time.Duration
-	checkInterval time.Duration
-}
-
-type idleConn struct {
-	conn interface{}
-	t    time.Time
+	inner *genericChannelPool[interface{}]
 }
 
 //NewChannelPool init connect pool
 func NewChannelPool(poolConfig *PoolConfig) (Pool, error) {
-	if poolConfig.InitialCap < 0 || poolConfig.MaxActive <= 0 || poolConfig.InitialCap > poolConfig.MaxActive {
-		return nil, errors.New("invalid capacity settings")
-	}
-	if poolConfig.Factory == nil {
-		return nil, errors.New("invalid factory func settings")
-	}
-	if poolConfig.Close == nil {
-		return nil, errors.New("invalid close func settings")
-	}
-
-	c := &channelPool{
-		conns:         make(chan *idleConn, poolConfig.MaxActive),
-		factory:       poolConfig.Factory,
-		close:         poolConfig.Close,
-		maxActive:     poolConfig.MaxActive,
-		maxIdle:       poolConfig.MaxIdle,
-		idleTimeout:   poolConfig.IdleTimeout,
-		checkInterval: poolConfig.CheckInterval,
-	}
-
-	if poolConfig.Ping != nil {
-		c.ping = poolConfig.Ping
-	}
-
-	for i := 0; i < poolConfig.InitialCap; i++ {
-		conn, err := c.factory()
-		if err != nil {
-			c.Release()
-			return nil, fmt.Errorf("factory is not able to fill the pool: %s", err)
-		}
-		c.curConnCount++
-		c.conns <- &idleConn{conn: conn, t: time.Now()}
-	}
-	if c.checkInterval > 0 {
-		go c.Check()
-	}
-
-	return c, nil
-}
-
-//getConns conn channel
-func (c *channelPool) getConns() chan *idleConn {
-	c.mu.Lock()
-	conns := c.conns
-	c.mu.Unlock()
-	return conns
-}
-
-// cur counter --
-func (c *channelPool) decrCurCount() {
-	c.mu.Lock()
-	c.curConnCount--
-	c.mu.Unlock()
-}
-
-// cur counter ++
-func (c *channelPool) incrCurCount() {
-	c.mu.Lock()
-	c.curConnCount++
-	c.mu.Unlock()
-}
-
-func (c *channelPool) Check() {
-	if c.idleTimeout == 0 {
-		return
-	}
-
-	judgeTimeout := func() {
-		if c.conns == nil {
-			return
-		}
-
-		for {
-			select {
-			case wrapConn := <-c.conns:
-				if wrapConn == nil {
-					break
-				}
-
-				c.mu.Lock()
-				if c.curConnCount > c.maxIdle {
-					killStats := wrapConn.t.Add(c.idleTimeout).Before(time.Now())
-					if killStats {
-						c.Close(wrapConn.conn)
-						c.curConnCount--
-						c.mu.Unlock()
-						continue
-					}
-				}
-				c.mu.Unlock()
-				c.conns <- wrapConn
-			default:
-				return
-			}
-		}
-	}
-
-	for {
-		time.Sleep(c.checkInterval)
-		judgeTimeout()
-	}
+	inner, err := NewGenericPool(&GenericPoolConfig[interface{}]{
+		InitialCap:    poolConfig.InitialCap,
+		MaxActive:     poolConfig.MaxActive,
+		MaxIdle:       poolConfig.MaxIdle,
+		MinIdle:       poolConfig.MinIdle,
+		Factory:       poolConfig.Factory,
+		Close:         poolConfig.Close,
+		Ping:          poolConfig.Ping,
+		OnBorrow:      poolConfig.OnBorrow,
+		TestOnReturn:  poolConfig.TestOnReturn,
+		IdleStrategy:  poolConfig.IdleStrategy,
+		IdleTimeout:   poolConfig.IdleTimeout,
+		CheckInterval: poolConfig.CheckInterval,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &channelPool{inner: inner.(*genericChannelPool[interface{}])}, nil
 }
 
 //Get get conn in pool
 func (c *channelPool) Get() (interface{}, error) {
-	if c.conns == nil {
-		return nil, ErrClosed
-	}
-
-	for {
-		select {
-		case wrapConn := <-c.conns:
-			if wrapConn == nil {
-				return nil, ErrClosed
-			}
-			if timeout := c.idleTimeout; timeout > 0 {
-				if wrapConn.t.Add(timeout).Before(time.Now()) {
-					c.Close(wrapConn.conn)
-					c.decrCurCount()
-					continue
-				}
-			}
-
-			if c.ping != nil {
-				if err := c.Ping(wrapConn.conn); err != nil {
-					continue
-				}
-			}
-			return wrapConn.conn, nil
-
-		default:
-			c.incrCurCount()
-			if c.curConnCount > c.maxActive {
-				c.decrCurCount()
-
-				time.Sleep(20 * time.Millisecond)
-				continue
-			}
-			conn, err := c.factory()
-			if err != nil {
-				c.decrCurCount()
-				return nil, err
-			}
+	return c.inner.Get()
+}
 
-			return conn, nil
-		}
-	}
+//GetContext get conn in pool, honoring ctx cancellation/deadline
+func (c *channelPool) GetContext(ctx context.Context) (interface{}, error) {
+	return c.inner.GetContext(ctx)
 }
 
 //Put put the connect to pool
@@ -193,23 +82,15 @@ func (c *channelPool) Put(conn interface{}) error {
 	if conn == nil {
 		return errors.New("connection is nil. rejecting")
 	}
+	return c.inner.Put(conn)
+}
 
-	c.mu.Lock()
-	defer c.mu.Unlock()
-
-	if c.conns == nil {
-		c.curConnCount--
-		return c.Close(conn)
-	}
-
-	select {
-	case c.conns <- &idleConn{conn: conn, t: time.Now()}:
-		return nil
-	default:
-		// connect pool is full, close the conn
-		c.curConnCount--
-		return c.Close(conn)
+//PutContext returns conn to the pool, see genericChannelPool[T].PutContext
+func (c *channelPool) PutContext(ctx context.Context, conn interface{}) error {
+	if conn == nil {
+		return errors.New("connection is nil. rejecting")
 	}
+	return c.inner.PutContext(ctx, conn)
 }
 
 //Close close a connect
@@ -217,43 +98,33 @@ func (c *channelPool) Close(conn interface{}) error {
 	if conn == nil {
 		return errors.New("connection is nil. rejecting")
 	}
-	return c.close(conn)
+	return c.inner.Close(conn)
 }
 
-//Ping try check connect
-func (c *channelPool) Ping(conn interface{}) error {
+//Remove discards conn and decrements the pool's connection count directly
+func (c *channelPool) Remove(conn interface{}) error {
 	if conn == nil {
 		return errors.New("connection is nil. rejecting")
 	}
-	return c.ping(conn)
+	return c.inner.Remove(conn)
 }
 
 //Release release all conn in pool
 func (c *channelPool) Release() {
-	c.mu.Lock()
-	conns := c.conns
-	c.conns = nil
-	c.factory = nil
-	closeFun := c.close
-	c.close = nil
-	c.mu.Unlock()
-
-	if conns == nil {
-		return
-	}
-
-	close(conns)
-	for wrapConn := range conns {
-		closeFun(wrapConn.conn)
-	}
+	c.inner.Release()
 }
 
 //Len conns's count in pool
 func (c *channelPool) Len() int {
-	return len(c.getConns())
+	return c.inner.Len()
 }
 
 //GetCurCount all conns count, contains not return pool
 func (c *channelPool) GetCurCount() int {
-	return c.curConnCount
+	return c.inner.GetCurCount()
+}
+
+//Stats returns a snapshot of the pool's hit/miss/wait counters.
+func (c *channelPool) Stats() *Stats {
+	return c.inner.Stats()
 }
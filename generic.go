@@ -0,0 +1,650 @@
+package pool
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// IdleStrategy selects which idle connection Get hands back first.
+type IdleStrategy int
+
+const (
+	// FIFO reuses the oldest idle connection first, which keeps every
+	// pooled connection warm. This is the pool's original, default
+	// behavior.
+	FIFO IdleStrategy = iota
+	// LIFO reuses the most recently returned connection first, leaving the
+	// rest sitting idle long enough for IdleTimeout here (or a server-side
+	// reaper) to actually close them. HikariCP and recent go-redis default
+	// to this for exactly that reason.
+	LIFO
+)
+
+// GenericPool[T] is the generic counterpart of Pool. It returns T directly
+// from Get/GetContext instead of interface{}, so callers built against a
+// single concrete connection type skip the type assertion (and the boxing
+// that comes with it) on every Get/Put. Pool itself is kept as a thin shim
+// over GenericPool[interface{}] for back-compat.
+type GenericPool[T any] interface {
+	Get() (T, error)
+	GetContext(ctx context.Context) (T, error)
+
+	Put(conn T) error
+	PutContext(ctx context.Context, conn T) error
+
+	Close(conn T) error
+
+	// Remove discards conn and decrements the pool's connection count
+	// directly, for callers that detect a broken conn mid-use and want it
+	// gone without going through Put's "is the channel full" logic.
+	Remove(conn T) error
+
+	Release()
+
+	Len() int
+
+	Stats() *Stats
+}
+
+// GenericPoolConfig[T] is the generic counterpart of PoolConfig.
+type GenericPoolConfig[T any] struct {
+	InitialCap int
+	MaxActive  int
+	MaxIdle    int
+	MinIdle    int
+
+	Factory      func() (T, error)
+	Close        func(T) error
+	Ping         func(T) error
+	OnBorrow     func(conn T, idleSince time.Time) error
+	TestOnReturn bool
+
+	// IdleStrategy picks FIFO (the default) or LIFO idle-connection reuse.
+	// See the IdleStrategy docs for why you'd want LIFO.
+	IdleStrategy IdleStrategy
+
+	IdleTimeout   time.Duration
+	CheckInterval time.Duration
+}
+
+type genericIdleConn[T any] struct {
+	conn T
+	t    time.Time
+}
+
+type genericChannelPool[T any] struct {
+	mu    sync.Mutex
+	conns chan *genericIdleConn[T] // FIFO idle store; nil when idleStrategy == LIFO
+	idle  []*genericIdleConn[T]    // LIFO idle store; nil when idleStrategy == FIFO
+	done  bool
+
+	waiters      *list.List // of chan *genericIdleConn[T], oldest waiter at Front()
+	idleStrategy IdleStrategy
+	maxActive    int
+	maxIdle      int
+	minIdle      int
+	curConnCount int
+
+	factory       func() (T, error)
+	close         func(T) error
+	ping          func(T) error
+	onBorrow      func(conn T, idleSince time.Time) error
+	testOnReturn  bool
+	idleTimeout   time.Duration
+	checkInterval time.Duration
+
+	hits              uint32
+	misses            uint32
+	timeouts          uint32
+	waitCount         uint32
+	waitDurationNanos int64
+}
+
+//NewGenericPool init a generic connect pool
+func NewGenericPool[T any](poolConfig *GenericPoolConfig[T]) (GenericPool[T], error) {
+	if poolConfig.InitialCap < 0 || poolConfig.MaxActive <= 0 || poolConfig.InitialCap > poolConfig.MaxActive {
+		return nil, errors.New("invalid capacity settings")
+	}
+	if poolConfig.Factory == nil {
+		return nil, errors.New("invalid factory func settings")
+	}
+	if poolConfig.Close == nil {
+		return nil, errors.New("invalid close func settings")
+	}
+
+	c := &genericChannelPool[T]{
+		waiters:       list.New(),
+		factory:       poolConfig.Factory,
+		close:         poolConfig.Close,
+		idleStrategy:  poolConfig.IdleStrategy,
+		maxActive:     poolConfig.MaxActive,
+		maxIdle:       poolConfig.MaxIdle,
+		minIdle:       poolConfig.MinIdle,
+		onBorrow:      poolConfig.OnBorrow,
+		testOnReturn:  poolConfig.TestOnReturn,
+		idleTimeout:   poolConfig.IdleTimeout,
+		checkInterval: poolConfig.CheckInterval,
+	}
+
+	if c.idleStrategy == LIFO {
+		c.idle = make([]*genericIdleConn[T], 0, poolConfig.MaxActive)
+	} else {
+		c.conns = make(chan *genericIdleConn[T], poolConfig.MaxActive)
+	}
+
+	if poolConfig.Ping != nil {
+		c.ping = poolConfig.Ping
+	}
+
+	for i := 0; i < poolConfig.InitialCap; i++ {
+		conn, err := c.factory()
+		if err != nil {
+			c.Release()
+			return nil, fmt.Errorf("factory is not able to fill the pool: %s", err)
+		}
+		c.curConnCount++
+		c.putIdleLocked(&genericIdleConn[T]{conn: conn, t: time.Now()})
+	}
+	if c.checkInterval > 0 {
+		go c.Check()
+	}
+
+	return c, nil
+}
+
+// takeIdleLocked must be called with c.mu held. ok is false if no idle conn
+// is available right now.
+func (c *genericChannelPool[T]) takeIdleLocked() (wrapConn *genericIdleConn[T], ok bool) {
+	if c.idleStrategy == LIFO {
+		n := len(c.idle)
+		if n == 0 {
+			return nil, false
+		}
+		wrapConn = c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		return wrapConn, true
+	}
+
+	select {
+	case wrapConn = <-c.conns:
+		return wrapConn, true
+	default:
+		return nil, false
+	}
+}
+
+// putIdleLocked must be called with c.mu held. It returns false if the idle
+// store is already at capacity, in which case the caller closes conn.
+func (c *genericChannelPool[T]) putIdleLocked(wrapConn *genericIdleConn[T]) bool {
+	if c.idleStrategy == LIFO {
+		if len(c.idle) >= c.maxActive {
+			return false
+		}
+		c.idle = append(c.idle, wrapConn)
+		return true
+	}
+
+	select {
+	case c.conns <- wrapConn:
+		return true
+	default:
+		return false
+	}
+}
+
+// idleLenLocked must be called with c.mu held.
+func (c *genericChannelPool[T]) idleLenLocked() int {
+	if c.idleStrategy == LIFO {
+		return len(c.idle)
+	}
+	return len(c.conns)
+}
+
+// drainIdleLocked must be called with c.mu held. It empties the idle store
+// and returns everything it held.
+func (c *genericChannelPool[T]) drainIdleLocked() []*genericIdleConn[T] {
+	if c.idleStrategy == LIFO {
+		items := c.idle
+		c.idle = make([]*genericIdleConn[T], 0, c.maxActive)
+		return items
+	}
+
+	items := make([]*genericIdleConn[T], 0, len(c.conns))
+	for {
+		select {
+		case wrapConn := <-c.conns:
+			items = append(items, wrapConn)
+		default:
+			return items
+		}
+	}
+}
+
+// cur counter --
+func (c *genericChannelPool[T]) decrCurCount() {
+	c.mu.Lock()
+	c.curConnCount--
+	c.mu.Unlock()
+}
+
+// cur counter ++
+func (c *genericChannelPool[T]) incrCurCount() {
+	c.mu.Lock()
+	c.curConnCount++
+	c.mu.Unlock()
+}
+
+// Check runs on its own goroutine every checkInterval. It evicts idle
+// connections that have outlived idleTimeout as well as ones that fail
+// Ping, then tops the pool back up to MinIdle via factory.
+func (c *genericChannelPool[T]) Check() {
+	for {
+		time.Sleep(c.checkInterval)
+		c.evictStaleConns()
+		c.refillMinIdle()
+	}
+}
+
+// evictStaleConns drains the idle store, closing connections that are
+// either past idleTimeout (while more than maxIdle are outstanding) or that
+// fail Ping, and puts the survivors back.
+func (c *genericChannelPool[T]) evictStaleConns() {
+	c.mu.Lock()
+	if c.done {
+		c.mu.Unlock()
+		return
+	}
+	items := c.drainIdleLocked()
+	// Release nils out c.close, so keep our own copy for closing conns
+	// after it may have run.
+	closeFun := c.close
+	c.mu.Unlock()
+
+	survivors := items[:0]
+	for _, wrapConn := range items {
+		c.mu.Lock()
+		expired := c.idleTimeout > 0 && c.curConnCount > c.maxIdle && wrapConn.t.Add(c.idleTimeout).Before(time.Now())
+		c.mu.Unlock()
+
+		dead := !expired && c.ping != nil && c.Ping(wrapConn.conn) != nil
+
+		if expired || dead {
+			closeFun(wrapConn.conn)
+			c.decrCurCount()
+			continue
+		}
+		survivors = append(survivors, wrapConn)
+	}
+
+	c.mu.Lock()
+	if c.done {
+		c.mu.Unlock()
+		// Release ran while we were Pinging; the survivors' conns were
+		// never handed to it, so close them ourselves instead of sending
+		// into a closed channel or resurrecting them after the pool died.
+		for _, wrapConn := range survivors {
+			closeFun(wrapConn.conn)
+			c.decrCurCount()
+		}
+		return
+	}
+	if c.idleStrategy == LIFO {
+		c.idle = append(survivors, c.idle...)
+	} else {
+		for _, wrapConn := range survivors {
+			c.conns <- wrapConn
+		}
+	}
+	c.mu.Unlock()
+}
+
+// refillMinIdle tops the idle store back up to MinIdle connections,
+// stopping if the pool is closed or MaxActive would be exceeded.
+func (c *genericChannelPool[T]) refillMinIdle() {
+	if c.minIdle <= 0 {
+		return
+	}
+
+	for {
+		c.mu.Lock()
+		if c.done || c.idleLenLocked() >= c.minIdle || c.curConnCount >= c.maxActive {
+			c.mu.Unlock()
+			return
+		}
+		closeFun := c.close // Release nils out c.close
+		c.curConnCount++
+		c.mu.Unlock()
+
+		conn, err := c.factory()
+		if err != nil {
+			c.decrCurCount()
+			return
+		}
+
+		c.mu.Lock()
+		done := c.done
+		ok := !done && c.putIdleLocked(&genericIdleConn[T]{conn: conn, t: time.Now()})
+		c.mu.Unlock()
+		if !ok {
+			// the pool was released while factory() was dialing, or the
+			// idle store filled up (e.g. a concurrent Put) in the
+			// meantime; don't leak the conn or send into a closed channel.
+			closeFun(conn)
+			c.decrCurCount()
+			return
+		}
+	}
+}
+
+//Get get conn in pool
+func (c *genericChannelPool[T]) Get() (T, error) {
+	return c.GetContext(context.Background())
+}
+
+//GetContext get conn in pool, honoring ctx cancellation/deadline while waiting
+//for a connection to become available. When the pool is exhausted the caller
+//is queued on a FIFO waiter list instead of busy-polling.
+func (c *genericChannelPool[T]) GetContext(ctx context.Context) (T, error) {
+	var zero T
+
+	for {
+		c.mu.Lock()
+		if c.done {
+			c.mu.Unlock()
+			return zero, ErrClosed
+		}
+
+		if wrapConn, ok := c.takeIdleLocked(); ok {
+			c.mu.Unlock()
+			if timeout := c.idleTimeout; timeout > 0 {
+				if wrapConn.t.Add(timeout).Before(time.Now()) {
+					c.Close(wrapConn.conn)
+					c.decrCurCount()
+					continue
+				}
+			}
+
+			if c.onBorrow != nil {
+				if err := c.onBorrow(wrapConn.conn, wrapConn.t); err != nil {
+					c.Close(wrapConn.conn)
+					c.decrCurCount()
+					continue
+				}
+			}
+			if c.ping != nil {
+				if err := c.Ping(wrapConn.conn); err != nil {
+					// the conn is leaving the idle store for good, so
+					// curConnCount must drop with it or it drifts out of
+					// sync with the store's real occupancy.
+					c.Close(wrapConn.conn)
+					c.decrCurCount()
+					continue
+				}
+			}
+			atomic.AddUint32(&c.hits, 1)
+			return wrapConn.conn, nil
+		}
+
+		c.curConnCount++
+		if c.curConnCount > c.maxActive {
+			c.curConnCount--
+
+			waitCh := make(chan *genericIdleConn[T], 1)
+			elem := c.waiters.PushBack(waitCh)
+			c.mu.Unlock()
+
+			atomic.AddUint32(&c.waitCount, 1)
+			start := time.Now()
+
+			select {
+			case wrapConn := <-waitCh:
+				atomic.AddInt64(&c.waitDurationNanos, int64(time.Since(start)))
+				if wrapConn == nil {
+					return zero, ErrClosed
+				}
+				if c.onBorrow != nil {
+					if err := c.onBorrow(wrapConn.conn, wrapConn.t); err != nil {
+						c.Close(wrapConn.conn)
+						c.decrCurCount()
+						continue
+					}
+				}
+				if c.ping != nil {
+					if err := c.Ping(wrapConn.conn); err != nil {
+						// same as the idle-store branch above: the conn is
+						// leaving for good, so it must be closed, not just
+						// dropped, or its underlying resource leaks.
+						c.Close(wrapConn.conn)
+						c.decrCurCount()
+						continue
+					}
+				}
+				return wrapConn.conn, nil
+
+			case <-ctx.Done():
+				atomic.AddInt64(&c.waitDurationNanos, int64(time.Since(start)))
+				atomic.AddUint32(&c.timeouts, 1)
+
+				c.mu.Lock()
+				c.waiters.Remove(elem)
+				c.mu.Unlock()
+
+				// a conn may have been handed to us in the race between the
+				// context firing and Put picking this waiter off the list.
+				select {
+				case wrapConn := <-waitCh:
+					if wrapConn != nil {
+						c.Put(wrapConn.conn)
+					}
+				default:
+				}
+
+				if ctx.Err() == context.Canceled {
+					return zero, context.Canceled
+				}
+				return zero, context.DeadlineExceeded
+			}
+		}
+
+		c.mu.Unlock()
+		conn, err := c.factory()
+		if err != nil {
+			c.decrCurCount()
+			return zero, err
+		}
+		atomic.AddUint32(&c.misses, 1)
+		return conn, nil
+	}
+}
+
+//Put put the connect to pool
+func (c *genericChannelPool[T]) Put(conn T) error {
+	return c.PutContext(context.Background(), conn)
+}
+
+//PutContext returns conn to the pool. If a caller is already waiting on
+//GetContext, the connection is handed to it directly instead of going
+//through the idle store. T's zero value isn't guarded against here since
+//T may not be comparable to nil; the interface{} shim does that check.
+func (c *genericChannelPool[T]) PutContext(ctx context.Context, conn T) error {
+	if c.testOnReturn && c.ping != nil {
+		if err := c.Ping(conn); err != nil {
+			c.decrCurCount()
+			return c.Close(conn)
+		}
+	}
+
+	c.mu.Lock()
+
+	if c.done {
+		c.curConnCount--
+		c.mu.Unlock()
+		return c.Close(conn)
+	}
+
+	wrapConn := &genericIdleConn[T]{conn: conn, t: time.Now()}
+
+	if elem := c.waiters.Front(); elem != nil {
+		c.waiters.Remove(elem)
+		waitCh := elem.Value.(chan *genericIdleConn[T])
+		c.mu.Unlock()
+		waitCh <- wrapConn
+		return nil
+	}
+
+	if c.putIdleLocked(wrapConn) {
+		c.mu.Unlock()
+		return nil
+	}
+
+	// the idle store is sized to maxActive, so it being full here means
+	// curConnCount already counted this conn against maxActive but nothing
+	// is waiting for it (the waiters check above came up empty) and there's
+	// no room left to hold it idle. The invariant PutContext maintains is:
+	// every conn counted in curConnCount is either out with a caller, held
+	// in the idle store, or (here) on its way to Close, and curConnCount is
+	// decremented exactly once for it.
+	c.curConnCount--
+	c.mu.Unlock()
+	return c.Close(conn)
+}
+
+//Remove discards conn and decrements curConnCount directly, for callers
+//that detect a broken conn mid-use and want it gone without running
+//PutContext's idle-store/waiter logic. Release nils out close as part of
+//shutting the pool down, so Remove checks done under the same lock and
+//no-ops instead of calling through it.
+func (c *genericChannelPool[T]) Remove(conn T) error {
+	c.mu.Lock()
+	if c.done {
+		c.mu.Unlock()
+		return ErrClosed
+	}
+	closeFun := c.close
+	c.curConnCount--
+	hasWaiter := c.waiters.Front() != nil
+	c.mu.Unlock()
+
+	closeErr := closeFun(conn)
+	if !hasWaiter {
+		return closeErr
+	}
+
+	// Removing a conn frees a slot under maxActive the same way Put does,
+	// but unlike Put there's no live conn left over to hand to whoever is
+	// already queued on GetContext. Without this, that waiter would block
+	// until an unrelated Put/Release happened to come along - possibly
+	// forever - while a later, unqueued Get() sails past it and dials a
+	// fresh conn straight from factory() since curConnCount dropped below
+	// maxActive again. Dial the replacement here and hand it off instead.
+	newConn, err := c.factory()
+	if err != nil {
+		// couldn't dial a replacement; leave the waiter (if it's still
+		// there) queued exactly as it was for a future Put/Remove/Release.
+		return closeErr
+	}
+
+	c.mu.Lock()
+	if c.done {
+		c.mu.Unlock()
+		closeFun(newConn)
+		return closeErr
+	}
+
+	elem := c.waiters.Front()
+	if elem == nil {
+		// the waiter gave up (ctx canceled) or was already served by a
+		// concurrent Put while factory() was dialing; fold the new conn
+		// into the idle store instead of leaking it.
+		if c.putIdleLocked(&genericIdleConn[T]{conn: newConn, t: time.Now()}) {
+			c.curConnCount++
+			c.mu.Unlock()
+			return closeErr
+		}
+		c.mu.Unlock()
+		closeFun(newConn)
+		return closeErr
+	}
+
+	c.waiters.Remove(elem)
+	waitCh := elem.Value.(chan *genericIdleConn[T])
+	c.curConnCount++
+	c.mu.Unlock()
+
+	waitCh <- &genericIdleConn[T]{conn: newConn, t: time.Now()}
+	return closeErr
+}
+
+//Close close a connect
+func (c *genericChannelPool[T]) Close(conn T) error {
+	return c.close(conn)
+}
+
+//Ping try check connect
+func (c *genericChannelPool[T]) Ping(conn T) error {
+	return c.ping(conn)
+}
+
+//Release release all conn in pool
+func (c *genericChannelPool[T]) Release() {
+	c.mu.Lock()
+	if c.done {
+		c.mu.Unlock()
+		return
+	}
+	c.done = true
+	items := c.drainIdleLocked()
+	c.factory = nil
+	closeFun := c.close
+	c.close = nil
+
+	for e := c.waiters.Front(); e != nil; e = e.Next() {
+		close(e.Value.(chan *genericIdleConn[T]))
+	}
+	c.waiters.Init()
+
+	if c.conns != nil {
+		close(c.conns)
+	}
+	c.mu.Unlock()
+
+	for _, wrapConn := range items {
+		closeFun(wrapConn.conn)
+	}
+}
+
+//Len conns's count in pool
+func (c *genericChannelPool[T]) Len() int {
+	c.mu.Lock()
+	n := c.idleLenLocked()
+	c.mu.Unlock()
+	return n
+}
+
+//GetCurCount all conns count, contains not return pool
+func (c *genericChannelPool[T]) GetCurCount() int {
+	return c.curConnCount
+}
+
+//Stats returns a snapshot of the pool's hit/miss/wait counters.
+func (c *genericChannelPool[T]) Stats() *Stats {
+	c.mu.Lock()
+	idle := c.idleLenLocked()
+	total := c.curConnCount
+	c.mu.Unlock()
+
+	return &Stats{
+		Hits:         atomic.LoadUint32(&c.hits),
+		Misses:       atomic.LoadUint32(&c.misses),
+		Timeouts:     atomic.LoadUint32(&c.timeouts),
+		WaitCount:    atomic.LoadUint32(&c.waitCount),
+		WaitDuration: time.Duration(atomic.LoadInt64(&c.waitDurationNanos)),
+		IdleConns:    uint32(idle),
+		TotalConns:   uint32(total),
+	}
+}
@@ -0,0 +1,82 @@
+package pool
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestMinIdleRefill(t *testing.T) {
+	var dialed int32
+	p, err := NewGenericPool(&GenericPoolConfig[int]{
+		MaxActive:     5,
+		MinIdle:       3,
+		CheckInterval: 10 * time.Millisecond,
+		Factory: func() (int, error) {
+			return int(atomic.AddInt32(&dialed, 1)), nil
+		},
+		Close: func(int) error { return nil },
+	})
+	if err != nil {
+		t.Fatalf("NewGenericPool: %v", err)
+	}
+	defer p.Release()
+
+	deadline := time.Now().Add(time.Second)
+	for p.Stats().IdleConns < 3 {
+		if time.Now().After(deadline) {
+			t.Fatalf("idle count never reached MinIdle, stats = %+v", *p.Stats())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if got := atomic.LoadInt32(&dialed); got != 3 {
+		t.Fatalf("factory dialed %d conns, want exactly MinIdle=3", got)
+	}
+}
+
+func TestOnBorrowDiscardsFailedConn(t *testing.T) {
+	var dialed, closed int32
+	var failNext int32
+	p, err := NewGenericPool(&GenericPoolConfig[int]{
+		MaxActive: 2,
+		Factory: func() (int, error) {
+			return int(atomic.AddInt32(&dialed, 1)), nil
+		},
+		Close: func(int) error {
+			atomic.AddInt32(&closed, 1)
+			return nil
+		},
+		OnBorrow: func(conn int, idleSince time.Time) error {
+			if atomic.LoadInt32(&failNext) == 1 {
+				return errors.New("validation failed")
+			}
+			return nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewGenericPool: %v", err)
+	}
+	defer p.Release()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := p.Put(conn); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	atomic.StoreInt32(&failNext, 1)
+	got, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get after OnBorrow starts failing: %v", err)
+	}
+	if got == conn {
+		t.Fatalf("Get returned the conn OnBorrow rejected, want a fresh one")
+	}
+	if atomic.LoadInt32(&closed) != 1 {
+		t.Fatalf("Close called %d times, want 1 (the conn OnBorrow discarded)", atomic.LoadInt32(&closed))
+	}
+}
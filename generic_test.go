@@ -0,0 +1,175 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waiterTestConfig builds a MaxActive=1 pool so a second Get always lands on
+// the waiter queue, which is what these tests exercise.
+func waiterTestConfig() *GenericPoolConfig[int] {
+	var n int32
+	return &GenericPoolConfig[int]{
+		MaxActive: 1,
+		Factory: func() (int, error) {
+			return int(atomic.AddInt32(&n, 1)), nil
+		},
+		Close: func(int) error { return nil },
+	}
+}
+
+func TestGetContextWaiterHandoff(t *testing.T) {
+	p, err := NewGenericPool(waiterTestConfig())
+	if err != nil {
+		t.Fatalf("NewGenericPool: %v", err)
+	}
+	defer p.Release()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	got := make(chan int, 1)
+	go func() {
+		c, err := p.GetContext(context.Background())
+		if err != nil {
+			t.Errorf("waiter GetContext: %v", err)
+			return
+		}
+		got <- c
+	}()
+
+	time.Sleep(20 * time.Millisecond) // give the goroutine time to register as a waiter
+	if err := p.Put(conn); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	select {
+	case c := <-got:
+		if c != conn {
+			t.Fatalf("waiter got %d, want the handed-off conn %d", c, conn)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiter never received the connection")
+	}
+}
+
+func TestGetContextWaiterPingFailureCloses(t *testing.T) {
+	cfg := waiterTestConfig()
+	var closed int32
+	cfg.Close = func(int) error {
+		atomic.AddInt32(&closed, 1)
+		return nil
+	}
+	var failPing int32
+	cfg.Ping = func(int) error {
+		if atomic.LoadInt32(&failPing) == 1 {
+			return errors.New("dead conn")
+		}
+		return nil
+	}
+
+	p, err := NewGenericPool(cfg)
+	if err != nil {
+		t.Fatalf("NewGenericPool: %v", err)
+	}
+	defer p.Release()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := p.GetContext(context.Background())
+		errCh <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	atomic.StoreInt32(&failPing, 1)
+	if err := p.Put(conn); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	select {
+	case <-errCh: // the waiter's Ping fails, falls through to a fresh factory() conn
+	case <-time.After(time.Second):
+		t.Fatal("waiter never returned")
+	}
+
+	if got := atomic.LoadInt32(&closed); got != 1 {
+		t.Fatalf("Close called %d times, want 1 (the dead conn handed to the waiter)", got)
+	}
+}
+
+func TestReleaseUnblocksWaiters(t *testing.T) {
+	p, err := NewGenericPool(waiterTestConfig())
+	if err != nil {
+		t.Fatalf("NewGenericPool: %v", err)
+	}
+
+	if _, err := p.Get(); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		_, err := p.GetContext(context.Background())
+		errCh <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	p.Release()
+
+	select {
+	case err := <-errCh:
+		if !errors.Is(err, ErrClosed) {
+			t.Fatalf("waiter error = %v, want ErrClosed", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Release did not unblock the waiting GetContext")
+	}
+}
+
+// TestRemoveServicesBlockedWaiter reproduces the starvation scenario: A
+// holds the only conn, B is already queued on GetContext, and A detects a
+// broken conn and calls Remove instead of Put. B must not be left blocked
+// forever on a slot nothing else will ever fill.
+func TestRemoveServicesBlockedWaiter(t *testing.T) {
+	p, err := NewGenericPool(waiterTestConfig())
+	if err != nil {
+		t.Fatalf("NewGenericPool: %v", err)
+	}
+	defer p.Release()
+
+	conn, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+
+	got := make(chan int, 1)
+	go func() {
+		c, err := p.GetContext(context.Background())
+		if err != nil {
+			t.Errorf("waiter GetContext: %v", err)
+			return
+		}
+		got <- c
+	}()
+
+	time.Sleep(20 * time.Millisecond) // give the goroutine time to register as a waiter
+	if err := p.Remove(conn); err != nil {
+		t.Fatalf("Remove: %v", err)
+	}
+
+	select {
+	case <-got:
+	case <-time.After(time.Second):
+		t.Fatal("waiter was never serviced after Remove freed its slot")
+	}
+}
@@ -0,0 +1,63 @@
+package pool
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestIdleStrategyOrdering(t *testing.T) {
+	cases := []struct {
+		name     string
+		strategy IdleStrategy
+		want     int // which of the 3 put-back conns (1, 2 or 3) Get should return first
+	}{
+		{"FIFO", FIFO, 1},
+		{"LIFO", LIFO, 3},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var n int32
+			p, err := NewGenericPool(&GenericPoolConfig[int]{
+				MaxActive:    3,
+				MaxIdle:      3,
+				IdleStrategy: tc.strategy,
+				Factory: func() (int, error) {
+					return int(atomic.AddInt32(&n, 1)), nil
+				},
+				Close: func(int) error { return nil },
+			})
+			if err != nil {
+				t.Fatalf("NewGenericPool: %v", err)
+			}
+			defer p.Release()
+
+			c1, err := p.Get()
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			c2, err := p.Get()
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			c3, err := p.Get()
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+
+			for _, c := range []int{c1, c2, c3} {
+				if err := p.Put(c); err != nil {
+					t.Fatalf("Put(%d): %v", c, err)
+				}
+			}
+
+			got, err := p.Get()
+			if err != nil {
+				t.Fatalf("Get: %v", err)
+			}
+			if got != tc.want {
+				t.Fatalf("first Get after returning 1,2,3 in order = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
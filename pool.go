@@ -1,6 +1,10 @@
 package pool
 
-import "errors"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 var (
 	ErrClosed = errors.New("pool is closed")
@@ -8,12 +12,35 @@ var (
 
 type Pool interface {
 	Get() (interface{}, error)
+	GetContext(ctx context.Context) (interface{}, error)
 
 	Put(interface{}) error
+	PutContext(ctx context.Context, conn interface{}) error
 
 	Close(interface{}) error
 
+	// Remove discards conn and decrements the pool's connection count
+	// directly, for callers that detect a broken conn mid-use and want it
+	// gone without going through Put's "is the channel full" logic. This is
+	// go-redis's Pooler.Remove, for the same reason.
+	Remove(interface{}) error
+
 	Release()
 
 	Len() int
+
+	Stats() *Stats
+}
+
+// Stats contains pool statistics, modeled after go-redis's Pooler.Stats().
+type Stats struct {
+	Hits     uint32 // number of times a free connection was found in the pool
+	Misses   uint32 // number of times a free connection was not found and a new one was created
+	Timeouts uint32 // number of times a GetContext call returned because its context was done
+
+	WaitCount    uint32        // number of callers that had to wait for a connection
+	WaitDuration time.Duration // cumulative time spent waiting for a connection
+
+	IdleConns  uint32 // number of idle connections in the pool
+	TotalConns uint32 // number of connections currently held by the pool (idle + in use)
 }
@@ -0,0 +1,210 @@
+package pool
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// ShardedPool wraps N independent channelPool shards so that Get/Put/
+// incrCurCount/decrCurCount no longer all contend on a single mutex under
+// high concurrency. Each shard owns its own conns channel, counter and
+// mutex; requests are spread across shards round-robin, similar to
+// firestack's MultConnPool[T].
+type ShardedPool struct {
+	shards []*channelPool
+	next   uint64
+
+	// owner tracks which shard a borrowed conn came from so PutContext can
+	// return it to the same shard's channel/counter. This only works if
+	// every conn GetContext ever hands out is unique under ==, which is
+	// why this package is built around pointer-typed connections
+	// (net.Conn, *sql.DB, ...) - two pointers are never == unless they
+	// really are the same connection. GetContext rejects conn types that
+	// aren't even comparable (slices, maps, funcs), but it can't detect
+	// value types (plain ints, strings, small structs) that just happen to
+	// compare equal across two distinct borrowed conns; using one of those
+	// as T will silently misroute PutContext/Remove to the wrong shard.
+	owner sync.Map // conn -> shard index (uint64)
+}
+
+// NewShardedPool builds a ShardedPool of `shards` independent channelPool
+// instances, each configured with a share of cfg's capacities so the totals
+// across all shards sum to exactly cfg's settings (never more). shards <= 0
+// defaults to runtime.GOMAXPROCS(0), capped at cfg.MaxActive since a shard
+// with MaxActive 0 can't be constructed. The returned Pool has the same
+// MaxActive ceiling as a single channelPool, just spread across shards to
+// cut mutex contention.
+func NewShardedPool(cfg *PoolConfig, shards int) (Pool, error) {
+	if shards <= 0 {
+		shards = runtime.GOMAXPROCS(0)
+	}
+	if cfg.MaxActive > 0 && shards > cfg.MaxActive {
+		shards = cfg.MaxActive
+	}
+
+	initialCaps := distribute(cfg.InitialCap, shards)
+	maxActives := distribute(cfg.MaxActive, shards)
+	maxIdles := distribute(cfg.MaxIdle, shards)
+	minIdles := distribute(cfg.MinIdle, shards)
+
+	sp := &ShardedPool{shards: make([]*channelPool, 0, shards)}
+	for i := 0; i < shards; i++ {
+		if initialCaps[i] > maxActives[i] {
+			initialCaps[i] = maxActives[i]
+		}
+
+		shardCfg := *cfg
+		shardCfg.InitialCap = initialCaps[i]
+		shardCfg.MaxActive = maxActives[i]
+		shardCfg.MaxIdle = maxIdles[i]
+		shardCfg.MinIdle = minIdles[i]
+
+		p, err := NewChannelPool(&shardCfg)
+		if err != nil {
+			sp.Release()
+			return nil, err
+		}
+		sp.shards = append(sp.shards, p.(*channelPool))
+	}
+
+	return sp, nil
+}
+
+// distribute splits n into `shards` non-negative shares that sum to exactly
+// n, handing the remainder out one per shard so no share differs from
+// another by more than 1.
+func distribute(n, shards int) []int {
+	out := make([]int, shards)
+	if n <= 0 {
+		return out
+	}
+	base, rem := n/shards, n%shards
+	for i := range out {
+		out[i] = base
+		if i < rem {
+			out[i]++
+		}
+	}
+	return out
+}
+
+func (sp *ShardedPool) shardAt(i uint64) *channelPool {
+	return sp.shards[i%uint64(len(sp.shards))]
+}
+
+//Get get conn from the next shard in round-robin order
+func (sp *ShardedPool) Get() (interface{}, error) {
+	return sp.GetContext(context.Background())
+}
+
+//GetContext get conn from the next shard in round-robin order, honoring ctx
+func (sp *ShardedPool) GetContext(ctx context.Context) (interface{}, error) {
+	idx := atomic.AddUint64(&sp.next, 1) - 1
+	shard := sp.shardAt(idx)
+
+	conn, err := shard.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !isComparable(conn) {
+		// can't track which shard this came from, so PutContext/Remove
+		// could never route it back correctly; discard it on the shard
+		// that dialed it rather than hand out a conn we can't reclaim.
+		shard.Remove(conn)
+		return nil, fmt.Errorf("pool: ShardedPool requires a comparable connection type to track shard ownership, got %T", conn)
+	}
+	sp.owner.Store(conn, idx%uint64(len(sp.shards)))
+	return conn, nil
+}
+
+// isComparable reports whether conn's dynamic type supports ==, which
+// sync.Map.Store requires of its key. Returns false for nil and for
+// slices/maps/funcs (and anything containing them).
+func isComparable(conn interface{}) bool {
+	if conn == nil {
+		return false
+	}
+	return reflect.TypeOf(conn).Comparable()
+}
+
+//Put return conn to the shard it was borrowed from
+func (sp *ShardedPool) Put(conn interface{}) error {
+	return sp.PutContext(context.Background(), conn)
+}
+
+//PutContext return conn to the shard it was borrowed from
+func (sp *ShardedPool) PutContext(ctx context.Context, conn interface{}) error {
+	if shardIdx, ok := sp.owner.Load(conn); ok {
+		sp.owner.Delete(conn)
+		return sp.shards[shardIdx.(uint64)].PutContext(ctx, conn)
+	}
+
+	// conn wasn't borrowed through this pool (or was already returned);
+	// fall back to round-robin placement rather than reject it.
+	idx := atomic.AddUint64(&sp.next, 1) - 1
+	return sp.shardAt(idx).PutContext(ctx, conn)
+}
+
+//Close close a connect. The close func is shared by every shard, so which
+//one services the call doesn't matter.
+func (sp *ShardedPool) Close(conn interface{}) error {
+	return sp.shards[0].Close(conn)
+}
+
+//Remove discards conn on the shard it was borrowed from, decrementing that
+//shard's connection count directly instead of going through Put
+func (sp *ShardedPool) Remove(conn interface{}) error {
+	if shardIdx, ok := sp.owner.Load(conn); ok {
+		sp.owner.Delete(conn)
+		return sp.shards[shardIdx.(uint64)].Remove(conn)
+	}
+
+	idx := atomic.AddUint64(&sp.next, 1) - 1
+	return sp.shardAt(idx).Remove(conn)
+}
+
+//Release release all conns in every shard
+func (sp *ShardedPool) Release() {
+	for _, shard := range sp.shards {
+		shard.Release()
+	}
+}
+
+//Len conns's count across all shards
+func (sp *ShardedPool) Len() int {
+	total := 0
+	for _, shard := range sp.shards {
+		total += shard.Len()
+	}
+	return total
+}
+
+//Stats aggregates Stats across all shards
+func (sp *ShardedPool) Stats() *Stats {
+	stats := &Stats{}
+	for _, shard := range sp.shards {
+		s := shard.Stats()
+		stats.Hits += s.Hits
+		stats.Misses += s.Misses
+		stats.Timeouts += s.Timeouts
+		stats.WaitCount += s.WaitCount
+		stats.WaitDuration += s.WaitDuration
+		stats.IdleConns += s.IdleConns
+		stats.TotalConns += s.TotalConns
+	}
+	return stats
+}
+
+//GetCurCount all conns count across every shard, contains not return pool
+func (sp *ShardedPool) GetCurCount() int {
+	total := 0
+	for _, shard := range sp.shards {
+		total += shard.GetCurCount()
+	}
+	return total
+}
@@ -0,0 +1,70 @@
+package pool
+
+import (
+	"testing"
+)
+
+func TestShardedPoolRoutesPutToOwningShard(t *testing.T) {
+	cfg := &PoolConfig{
+		MaxActive: 3,
+		MaxIdle:   3,
+		Factory: func() (interface{}, error) {
+			v := new(int)
+			return v, nil
+		},
+		Close: func(interface{}) error { return nil },
+	}
+
+	p, err := NewShardedPool(cfg, 3)
+	if err != nil {
+		t.Fatalf("NewShardedPool: %v", err)
+	}
+	defer p.Release()
+
+	// Round-robin hands these to shard 0, 1, 2 respectively.
+	conn0, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get (shard 0): %v", err)
+	}
+	if _, err := p.Get(); err != nil { // shard 1
+		t.Fatalf("Get (shard 1): %v", err)
+	}
+	if _, err := p.Get(); err != nil { // shard 2
+		t.Fatalf("Get (shard 2): %v", err)
+	}
+
+	if err := p.Put(conn0); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	// The 4th Get wraps back around to shard 0. If Put had routed conn0 to
+	// the wrong shard, shard 0's idle store would be empty here and this
+	// would dial a brand new conn instead of handing conn0 back.
+	again, err := p.Get()
+	if err != nil {
+		t.Fatalf("Get (wrap to shard 0): %v", err)
+	}
+	if again != conn0 {
+		t.Fatalf("Get after Put returned %v, want the same conn %v routed back to its owning shard", again, conn0)
+	}
+}
+
+func TestShardedPoolRejectsNonComparableConn(t *testing.T) {
+	cfg := &PoolConfig{
+		MaxActive: 2,
+		Factory: func() (interface{}, error) {
+			return []int{1, 2, 3}, nil // slices aren't comparable
+		},
+		Close: func(interface{}) error { return nil },
+	}
+
+	p, err := NewShardedPool(cfg, 2)
+	if err != nil {
+		t.Fatalf("NewShardedPool: %v", err)
+	}
+	defer p.Release()
+
+	if _, err := p.Get(); err == nil {
+		t.Fatal("Get with a non-comparable conn type succeeded, want an error instead of a later panic in sync.Map")
+	}
+}